@@ -0,0 +1,128 @@
+// Command repro-replay walks a directory of request captures written by
+// the reproducer subsystem and re-issues each one against a target URL,
+// printing a diff of the response body against the one recorded at
+// capture time. By default it exits non-zero if any replay still
+// reproduces its captured failure byte-for-byte, so it can gate a staging
+// deploy on "did we actually fix this." Pass -expect-failure to invert
+// that — useful when you're confirming a capture still repros the bug
+// before you start working on a fix.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/whisper-sagemaker/api/src/reproducer"
+)
+
+func main() {
+	dir := flag.String("dir", "", "directory of captured requests (REPRODUCER_DIR)")
+	target := flag.String("target", "", "base URL to replay requests against, e.g. http://staging:8080")
+	expectFailure := flag.Bool("expect-failure", false, "exit non-zero when a replay does NOT reproduce its captured failure, instead of the default (exit non-zero when it does)")
+	flag.Parse()
+
+	if *dir == "" || *target == "" {
+		fmt.Fprintln(os.Stderr, "usage: repro-replay -dir <captures> -target <base-url>")
+		os.Exit(2)
+	}
+
+	failures := 0
+	total := 0
+
+	entries, err := os.ReadDir(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "repro-replay: read %s: %v\n", *dir, err)
+		os.Exit(1)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		total++
+
+		capturePath := filepath.Join(*dir, entry.Name())
+		reproduced, err := replay(capturePath, *target)
+		if err != nil {
+			fmt.Printf("%s: ERROR %v\n", entry.Name(), err)
+			failures++
+			continue
+		}
+
+		failed := reproduced
+		if *expectFailure {
+			failed = !reproduced
+		}
+		if failed {
+			failures++
+		}
+	}
+
+	if *expectFailure {
+		fmt.Printf("\n%d/%d replays reproduced their capture\n", total-failures, total)
+	} else {
+		fmt.Printf("\n%d/%d replays came back fixed\n", total-failures, total)
+	}
+	if failures > 0 {
+		os.Exit(1)
+	}
+}
+
+// replay re-issues the request captured at capturePath against target and
+// reports whether the response reproduced the original failure
+// byte-for-byte. It does not itself decide pass/fail — main does, since
+// that depends on -expect-failure.
+func replay(capturePath, target string) (reproduced bool, err error) {
+	metaBytes, err := os.ReadFile(filepath.Join(capturePath, reproducer.MetaFileName))
+	if err != nil {
+		return false, fmt.Errorf("read meta: %w", err)
+	}
+	var meta reproducer.Meta
+	if err := json.Unmarshal(metaBytes, &meta); err != nil {
+		return false, fmt.Errorf("parse meta: %w", err)
+	}
+
+	body, err := os.ReadFile(filepath.Join(capturePath, reproducer.BodyFileName))
+	if err != nil {
+		return false, fmt.Errorf("read body: %w", err)
+	}
+
+	req, err := http.NewRequest(meta.Method, target+meta.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("build request: %w", err)
+	}
+	for key, values := range meta.Headers {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("replay request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	replayedBody := new(bytes.Buffer)
+	if _, err := replayedBody.ReadFrom(resp.Body); err != nil {
+		return false, fmt.Errorf("read replayed response: %w", err)
+	}
+
+	reproduced = resp.StatusCode == meta.OriginalStatus && replayedBody.String() == meta.OriginalResponse
+	status := "DIFF"
+	if reproduced {
+		status = "MATCH"
+	}
+	fmt.Printf("%s: %s (captured %d, replayed %d)\n", filepath.Base(capturePath), status, meta.OriginalStatus, resp.StatusCode)
+	if !reproduced {
+		fmt.Printf("  captured response: %s\n", meta.OriginalResponse)
+		fmt.Printf("  replayed response: %s\n", replayedBody.String())
+	}
+
+	return reproduced, nil
+}
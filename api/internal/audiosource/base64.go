@@ -0,0 +1,43 @@
+package audiosource
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+)
+
+// base64Source decodes audio embedded directly in the JSON request body.
+type base64Source struct {
+	data     string
+	filename string
+	opts     Options
+}
+
+func NewBase64Source(data, filename string, opts Options) Source {
+	return &base64Source{data: data, filename: filename, opts: opts}
+}
+
+func (s *base64Source) Open(ctx context.Context) (io.ReadCloser, string, int64, error) {
+	// filename has no fallback (there's no upload or URL path to sniff an
+	// extension from), so it's required here even though the JSON field
+	// is optional for the other sources — otherwise this is the one
+	// input path that could bypass the allowed-extension allowlist.
+	if s.filename == "" {
+		return nil, "", 0, &ValidationError{Message: "audio_base64 requires filename to be set"}
+	}
+	if err := validateExtension(s.filename, s.opts.AllowedExtensions); err != nil {
+		return nil, "", 0, err
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(s.data)
+	if err != nil {
+		return nil, "", 0, &ValidationError{Message: "invalid audio_base64 payload"}
+	}
+
+	if err := validateSize(int64(len(decoded)), s.opts.MaxFileSize); err != nil {
+		return nil, "", 0, err
+	}
+
+	return io.NopCloser(bytes.NewReader(decoded)), s.filename, int64(len(decoded)), nil
+}
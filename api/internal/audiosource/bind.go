@@ -0,0 +1,68 @@
+package audiosource
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// jsonPayload is the body accepted for application/json requests: exactly
+// one of the three fields should be set.
+type jsonPayload struct {
+	S3URI       string `json:"s3_uri"`
+	URL         string `json:"url"`
+	AudioBase64 string `json:"audio_base64"`
+	Filename    string `json:"filename"`
+}
+
+// Bind inspects r's Content-Type and builds the matching Source, modeled
+// after Echo's DefaultBinder dispatching on content type. s3Client is only
+// used for the application/json {"s3_uri": ...} case.
+func Bind(r *http.Request, opts Options, s3Client *s3.Client) (Source, error) {
+	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		contentType = r.Header.Get("Content-Type")
+	}
+
+	switch contentType {
+	case "multipart/form-data":
+		return NewMultipartSource(r, opts), nil
+
+	case "application/octet-stream":
+		return NewRawSource(r, opts), nil
+
+	case "application/json":
+		// Base64 inflates the encoded audio by ~4/3; cap the raw body a
+		// little past that so a legitimate audio_base64 payload at the
+		// size limit isn't rejected by the body cap before validateSize
+		// ever sees the decoded bytes.
+		body := limitReader(r.Body, opts.MaxFileSize*4/3+4096)
+		defer body.Close()
+
+		var payload jsonPayload
+		if err := json.NewDecoder(body).Decode(&payload); err != nil {
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) {
+				return nil, validationErr
+			}
+			return nil, &ValidationError{Message: "invalid JSON body"}
+		}
+		switch {
+		case payload.S3URI != "":
+			return NewS3Source(s3Client, payload.S3URI, opts), nil
+		case payload.URL != "":
+			return NewURLSource(payload.URL, opts), nil
+		case payload.AudioBase64 != "":
+			return NewBase64Source(payload.AudioBase64, payload.Filename, opts), nil
+		default:
+			return nil, &ValidationError{Message: "JSON body must set one of s3_uri, url, or audio_base64"}
+		}
+
+	default:
+		return nil, &ValidationError{Message: fmt.Sprintf("unsupported content type: %s", contentType)}
+	}
+}
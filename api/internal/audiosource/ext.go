@@ -0,0 +1,10 @@
+package audiosource
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+func extOf(filename string) string {
+	return strings.ToLower(filepath.Ext(filename))
+}
@@ -0,0 +1,41 @@
+package audiosource
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// multipartSource reads the "audio" field of a multipart/form-data
+// request — the original, and still default, upload path.
+type multipartSource struct {
+	r    *http.Request
+	opts Options
+}
+
+func NewMultipartSource(r *http.Request, opts Options) Source {
+	return &multipartSource{r: r, opts: opts}
+}
+
+func (s *multipartSource) Open(ctx context.Context) (io.ReadCloser, string, int64, error) {
+	if err := s.r.ParseMultipartForm(s.opts.MaxFileSize); err != nil {
+		return nil, "", 0, &ValidationError{Message: fmt.Sprintf("failed to parse form data: %v", err)}
+	}
+
+	file, header, err := s.r.FormFile("audio")
+	if err != nil {
+		return nil, "", 0, &ValidationError{Message: fmt.Sprintf("no audio file provided: %v", err)}
+	}
+
+	if err := validateExtension(header.Filename, s.opts.AllowedExtensions); err != nil {
+		file.Close()
+		return nil, "", 0, err
+	}
+	if err := validateSize(header.Size, s.opts.MaxFileSize); err != nil {
+		file.Close()
+		return nil, "", 0, err
+	}
+
+	return file, header.Filename, header.Size, nil
+}
@@ -0,0 +1,31 @@
+package audiosource
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// rawSource reads audio straight from an application/octet-stream request
+// body; the filename (needed for extension validation) comes from the
+// X-Filename header since the body itself carries none.
+type rawSource struct {
+	r    *http.Request
+	opts Options
+}
+
+func NewRawSource(r *http.Request, opts Options) Source {
+	return &rawSource{r: r, opts: opts}
+}
+
+func (s *rawSource) Open(ctx context.Context) (io.ReadCloser, string, int64, error) {
+	filename := s.r.Header.Get("X-Filename")
+	if err := validateExtension(filename, s.opts.AllowedExtensions); err != nil {
+		return nil, "", 0, err
+	}
+	if err := validateSize(s.r.ContentLength, s.opts.MaxFileSize); err != nil {
+		return nil, "", 0, err
+	}
+
+	return limitReader(s.r.Body, s.opts.MaxFileSize), filename, s.r.ContentLength, nil
+}
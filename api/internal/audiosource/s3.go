@@ -0,0 +1,69 @@
+package audiosource
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// s3Source fetches an object already sitting in S3, so pipelines that
+// staged audio there don't need to upload it a second time.
+type s3Source struct {
+	client *s3.Client
+	uri    string
+	opts   Options
+}
+
+func NewS3Source(client *s3.Client, uri string, opts Options) Source {
+	return &s3Source{client: client, uri: uri, opts: opts}
+}
+
+func (s *s3Source) Open(ctx context.Context) (io.ReadCloser, string, int64, error) {
+	bucket, key, err := ParseS3URI(s.uri)
+	if err != nil {
+		return nil, "", 0, &ValidationError{Message: err.Error()}
+	}
+
+	filename := path.Base(key)
+	if err := validateExtension(filename, s.opts.AllowedExtensions); err != nil {
+		return nil, "", 0, err
+	}
+
+	result, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("failed to fetch %s: %w", s.uri, err)
+	}
+
+	size := int64(-1)
+	if result.ContentLength != nil {
+		size = *result.ContentLength
+	}
+	if err := validateSize(size, s.opts.MaxFileSize); err != nil {
+		result.Body.Close()
+		return nil, "", 0, err
+	}
+
+	return limitReader(result.Body, s.opts.MaxFileSize), filename, size, nil
+}
+
+// ParseS3URI splits "s3://bucket/key/path" into its bucket and key parts.
+func ParseS3URI(uri string) (bucket, key string, err error) {
+	const scheme = "s3://"
+	if !strings.HasPrefix(uri, scheme) {
+		return "", "", fmt.Errorf("not an s3 uri: %s", uri)
+	}
+	rest := strings.TrimPrefix(uri, scheme)
+	parts := strings.SplitN(rest, "/", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", "", fmt.Errorf("malformed s3 uri: %s", uri)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,91 @@
+// Package audiosource abstracts over the different ways audio can reach
+// the transcription handler: a multipart upload, a raw request body, an
+// object already sitting in S3, a URL the server should fetch, or base64
+// audio embedded in a JSON body. Binding
+// dispatches on Content-Type the way Echo's DefaultBinder picks a decoder,
+// and format/size validation lives here so every input path enforces the
+// same limits.
+package audiosource
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Options bounds what a Source is allowed to produce.
+type Options struct {
+	AllowedExtensions []string
+	MaxFileSize       int64
+}
+
+// Source lazily opens an audio payload. Filename is used for extension
+// validation and is passed through to SageMaker logging; size is the
+// known content length, or -1 if it can only be known after reading.
+type Source interface {
+	Open(ctx context.Context) (reader io.ReadCloser, filename string, size int64, err error)
+}
+
+// ValidationError is returned by a Source when the payload fails format or
+// size checks; handlers map it to a 400 response.
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string { return e.Message }
+
+func validateExtension(filename string, allowed []string) error {
+	ext := extOf(filename)
+	for _, a := range allowed {
+		if a == ext {
+			return nil
+		}
+	}
+	return &ValidationError{Message: fmt.Sprintf("unsupported audio format: %s. Allowed formats: %v", ext, allowed)}
+}
+
+func validateSize(size, maxFileSize int64) error {
+	if size > maxFileSize {
+		return sizeLimitError(maxFileSize)
+	}
+	return nil
+}
+
+func sizeLimitError(maxFileSize int64) error {
+	return &ValidationError{Message: fmt.Sprintf("file size exceeds maximum of %d MB", maxFileSize/(1024*1024))}
+}
+
+// limitedReadCloser enforces a hard cap on bytes read from an underlying
+// stream. validateSize only checks a size that's known in advance (a
+// Content-Length header, an S3 object's metadata); a chunked request body
+// or a lying upstream server can make that check a no-op, so every source
+// that reads from the network also wraps its stream in this before
+// returning it.
+type limitedReadCloser struct {
+	rc  io.ReadCloser
+	max int64
+	n   int64
+}
+
+// limitReader wraps rc so that reading past max bytes fails with the same
+// ValidationError validateSize would have returned, instead of silently
+// reading an unbounded stream into memory.
+func limitReader(rc io.ReadCloser, max int64) io.ReadCloser {
+	return &limitedReadCloser{rc: rc, max: max}
+}
+
+func (l *limitedReadCloser) Read(p []byte) (int, error) {
+	if l.n > l.max {
+		return 0, sizeLimitError(l.max)
+	}
+	n, err := l.rc.Read(p)
+	l.n += int64(n)
+	if l.n > l.max {
+		return n, sizeLimitError(l.max)
+	}
+	return n, err
+}
+
+func (l *limitedReadCloser) Close() error {
+	return l.rc.Close()
+}
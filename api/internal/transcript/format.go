@@ -0,0 +1,62 @@
+package transcript
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RenderVTT renders segments as a WebVTT caption track.
+func RenderVTT(segments []Segment) string {
+	var b strings.Builder
+	b.WriteString("WEBVTT\n\n")
+	for _, seg := range segments {
+		fmt.Fprintf(&b, "%s --> %s\n%s\n\n", vttTimestamp(seg.Start), vttTimestamp(seg.End), seg.Text)
+	}
+	return b.String()
+}
+
+// RenderSRT renders segments as a SubRip (.srt) caption track.
+func RenderSRT(segments []Segment) string {
+	var b strings.Builder
+	for i, seg := range segments {
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n\n", i+1, srtTimestamp(seg.Start), srtTimestamp(seg.End), seg.Text)
+	}
+	return b.String()
+}
+
+// RenderPlain concatenates segment text as plain, caption-free text. It
+// falls back to fallback (typically the transcription's full text) when
+// there are no segments to join, e.g. when timestamps weren't requested.
+func RenderPlain(segments []Segment, fallback string) string {
+	if len(segments) == 0 {
+		return fallback
+	}
+	parts := make([]string, len(segments))
+	for i, seg := range segments {
+		parts[i] = seg.Text
+	}
+	return strings.Join(parts, " ")
+}
+
+// vttTimestamp renders seconds as WebVTT's HH:MM:SS.mmm cue timing.
+func vttTimestamp(seconds float64) string {
+	h, m, s, ms := splitDuration(seconds)
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", h, m, s, ms)
+}
+
+// srtTimestamp renders seconds as SRT's HH:MM:SS,mmm cue timing.
+func srtTimestamp(seconds float64) string {
+	h, m, s, ms := splitDuration(seconds)
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", h, m, s, ms)
+}
+
+func splitDuration(seconds float64) (h, m, s, ms int) {
+	totalMs := int64(seconds*1000 + 0.5)
+	ms = int(totalMs % 1000)
+	totalSeconds := totalMs / 1000
+	s = int(totalSeconds % 60)
+	totalMinutes := totalSeconds / 60
+	m = int(totalMinutes % 60)
+	h = int(totalMinutes / 60)
+	return h, m, s, ms
+}
@@ -0,0 +1,115 @@
+package transcript
+
+import "testing"
+
+func TestSplitDuration(t *testing.T) {
+	tests := []struct {
+		name        string
+		seconds     float64
+		h, m, s, ms int
+	}{
+		{name: "zero", seconds: 0, h: 0, m: 0, s: 0, ms: 0},
+		{name: "sub-second", seconds: 0.25, h: 0, m: 0, s: 0, ms: 250},
+		{name: "whole seconds", seconds: 61, h: 0, m: 1, s: 1, ms: 0},
+		{name: "over an hour", seconds: 3723.5, h: 1, m: 2, s: 3, ms: 500},
+		{name: "rounds to the nearest millisecond", seconds: 1.0005, h: 0, m: 0, s: 1, ms: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, m, s, ms := splitDuration(tt.seconds)
+			if h != tt.h || m != tt.m || s != tt.s || ms != tt.ms {
+				t.Errorf("splitDuration(%v) = %02d:%02d:%02d.%03d, want %02d:%02d:%02d.%03d",
+					tt.seconds, h, m, s, ms, tt.h, tt.m, tt.s, tt.ms)
+			}
+		})
+	}
+}
+
+func TestRenderVTT(t *testing.T) {
+	tests := []struct {
+		name     string
+		segments []Segment
+		want     string
+	}{
+		{
+			name:     "empty segments",
+			segments: nil,
+			want:     "WEBVTT\n\n",
+		},
+		{
+			name:     "zero-duration cue",
+			segments: []Segment{{Start: 0, End: 0, Text: "hi"}},
+			want:     "WEBVTT\n\n00:00:00.000 --> 00:00:00.000\nhi\n\n",
+		},
+		{
+			name:     "cue past an hour",
+			segments: []Segment{{Start: 3661.25, End: 3662.5, Text: "late"}},
+			want:     "WEBVTT\n\n01:01:01.250 --> 01:01:02.500\nlate\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderVTT(tt.segments); got != tt.want {
+				t.Errorf("RenderVTT(%+v) = %q, want %q", tt.segments, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderSRT(t *testing.T) {
+	tests := []struct {
+		name     string
+		segments []Segment
+		want     string
+	}{
+		{
+			name:     "empty segments",
+			segments: nil,
+			want:     "",
+		},
+		{
+			name:     "zero-duration cue",
+			segments: []Segment{{Start: 0, End: 0, Text: "hi"}},
+			want:     "1\n00:00:00,000 --> 00:00:00,000\nhi\n\n",
+		},
+		{
+			name: "multiple cues are numbered in order",
+			segments: []Segment{
+				{Start: 0, End: 1, Text: "one"},
+				{Start: 3661.25, End: 3662.5, Text: "two"},
+			},
+			want: "1\n00:00:00,000 --> 00:00:01,000\none\n\n" +
+				"2\n01:01:01,250 --> 01:01:02,500\ntwo\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderSRT(tt.segments); got != tt.want {
+				t.Errorf("RenderSRT(%+v) = %q, want %q", tt.segments, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderPlain(t *testing.T) {
+	tests := []struct {
+		name     string
+		segments []Segment
+		fallback string
+		want     string
+	}{
+		{name: "empty segments fall back", segments: nil, fallback: "full text", want: "full text"},
+		{name: "joins segment text", segments: []Segment{{Text: "one"}, {Text: "two"}}, fallback: "unused", want: "one two"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderPlain(tt.segments, tt.fallback); got != tt.want {
+				t.Errorf("RenderPlain(%+v, %q) = %q, want %q", tt.segments, tt.fallback, got, tt.want)
+			}
+		})
+	}
+}
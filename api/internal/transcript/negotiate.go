@@ -0,0 +1,48 @@
+package transcript
+
+import "strings"
+
+// Format identifies how a transcription response should be rendered.
+type Format string
+
+const (
+	FormatJSON  Format = "application/json"
+	FormatVTT   Format = "text/vtt"
+	FormatSRT   Format = "application/x-subrip"
+	FormatPlain Format = "text/plain"
+	FormatSSE   Format = "text/event-stream"
+)
+
+// queryFormats maps the short ?format= query values to their Format.
+var queryFormats = map[string]Format{
+	"json": FormatJSON,
+	"vtt":  FormatVTT,
+	"srt":  FormatSRT,
+	"text": FormatPlain,
+	"sse":  FormatSSE,
+}
+
+// Negotiate picks a Format from the ?format= query param first, falling
+// back to the Accept header, and defaulting to JSON when neither names a
+// format this package knows how to render.
+func Negotiate(acceptHeader, queryParam string) Format {
+	if format, ok := queryFormats[strings.ToLower(queryParam)]; ok {
+		return format
+	}
+
+	for _, media := range strings.Split(acceptHeader, ",") {
+		media = strings.TrimSpace(strings.SplitN(media, ";", 2)[0])
+		switch Format(media) {
+		case FormatVTT, FormatSRT, FormatPlain, FormatSSE, FormatJSON:
+			return Format(media)
+		}
+	}
+
+	return FormatJSON
+}
+
+// NeedsTimestamps reports whether f requires segment/word-level timing
+// from the SageMaker payload builder.
+func (f Format) NeedsTimestamps() bool {
+	return f != FormatJSON
+}
@@ -0,0 +1,20 @@
+// Package transcript renders SageMaker's segment-level transcription
+// output as WebVTT or SRT captions, or negotiates plain text/JSON/SSE
+// output for clients that don't need cue timing.
+package transcript
+
+// Word is a single word-level timestamp within a Segment.
+type Word struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Text  string  `json:"text"`
+}
+
+// Segment is one chunk of transcribed audio with timing information, as
+// returned by the SageMaker endpoint when return_timestamps is requested.
+type Segment struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Text  string  `json:"text"`
+	Words []Word  `json:"words,omitempty"`
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"net/http"
 
 	"github.com/whisper-sagemaker/api/src/config"
@@ -14,19 +13,22 @@ func main() {
 	cfg := config.Load()
 
 	// Initialize logger
-	logger := utils.NewLogger()
-	logger.Info("Starting Whisper SageMaker API...")
-	logger.Info(fmt.Sprintf("SageMaker Endpoint: %s", cfg.SageMakerEndpoint))
-	logger.Info(fmt.Sprintf("AWS Region: %s", cfg.AWSRegion))
+	logger := utils.NewLogger(cfg.LogLevel)
+	defer logger.Sync()
+
+	logger.Info("Starting Whisper SageMaker API...",
+		"sagemaker_endpoint", cfg.SageMakerEndpoint,
+		"aws_region", cfg.AWSRegion,
+	)
 
 	// Setup routes
 	router := routes.SetupRoutes(cfg, logger)
 
 	// Start server
 	addr := ":" + cfg.Port
-	logger.Info(fmt.Sprintf("Server listening on %s", addr))
+	logger.Info("Server listening", "addr", addr)
 
 	if err := http.ListenAndServe(addr, router); err != nil {
-		logger.Fatal("Server failed to start:", err)
+		logger.Fatal("Server failed to start", "error", err)
 	}
 }
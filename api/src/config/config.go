@@ -4,25 +4,50 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 )
 
 type Config struct {
-	Port                 string
-	SageMakerEndpoint    string
-	AWSRegion            string
-	MaxFileSize          int64
-	AllowedOrigins       []string
-	LogLevel             string
+	Port                string
+	SageMakerEndpoint   string
+	AWSRegion           string
+	MaxFileSize         int64
+	AllowedOrigins      []string
+	LogLevel            string
+	HTTPLogEnabled      bool
+	HTTPLogMaxBody      int64
+	HTTPLogPath         string
+	HTTPLogMaxSize      int
+	HTTPLogUseGzip      bool
+	AsyncS3InputURI     string
+	JobStoreRedisAddr   string
+	JobStoreRedisPrefix string
+	ReproducerEnabled   bool
+	ReproducerDir       string
+	ReproducerMaxCount  int
+	ReproducerMaxBytes  int64
 }
 
 func Load() *Config {
 	cfg := &Config{
-		Port:              getEnv("PORT", "8080"),
-		SageMakerEndpoint: getEnv("SAGEMAKER_ENDPOINT_NAME", ""),
-		AWSRegion:         getEnv("AWS_REGION", "eu-west-1"),
-		MaxFileSize:       getEnvAsInt64("MAX_FILE_SIZE", 100*1024*1024), // 100MB default
-		AllowedOrigins:    []string{getEnv("ALLOWED_ORIGINS", "*")},
-		LogLevel:          getEnv("LOG_LEVEL", "info"),
+		Port:                getEnv("PORT", "8080"),
+		SageMakerEndpoint:   getEnv("SAGEMAKER_ENDPOINT_NAME", ""),
+		AWSRegion:           getEnv("AWS_REGION", "eu-west-1"),
+		MaxFileSize:         getEnvAsInt64("MAX_FILE_SIZE", 100*1024*1024), // 100MB default
+		AllowedOrigins:      []string{getEnv("ALLOWED_ORIGINS", "*")},
+		LogLevel:            getEnv("LOG_LEVEL", "info"),
+		HTTPLogEnabled:      getEnvAsBool("HTTP_LOG_ENABLED", false),
+		HTTPLogMaxBody:      getEnvAsInt64("HTTP_LOG_MAX_BODY", 64*1024), // 64KB default
+		HTTPLogPath:         getEnv("HTTP_LOG_PATH", "/var/log/whisper/http-audit.log"),
+		HTTPLogMaxSize:      int(getEnvAsInt64("HTTP_LOG_MAX_SIZE", 100)), // MB default
+		HTTPLogUseGzip:      getEnvAsBool("HTTP_LOG_USE_GZIP", true),
+		AsyncS3InputURI:     getEnv("ASYNC_S3_INPUT_URI", ""),
+		JobStoreRedisAddr:   getEnv("JOB_STORE_REDIS_ADDR", ""),
+		JobStoreRedisPrefix: getEnv("JOB_STORE_REDIS_PREFIX", "whisper:job:"),
+		ReproducerEnabled:   getEnvAsBool("REPRODUCER_ENABLED", false),
+		ReproducerDir:       getEnv("REPRODUCER_DIR", "/var/lib/whisper/repro"),
+		ReproducerMaxCount:  int(getEnvAsInt64("REPRODUCER_MAX_COUNT", 100)),
+		ReproducerMaxBytes:  getEnvAsInt64("REPRODUCER_MAX_BYTES", 500*1024*1024), // 500MB default
 	}
 
 	if cfg.SageMakerEndpoint == "" {
@@ -51,3 +76,15 @@ func getEnvAsInt64(key string, defaultValue int64) int64 {
 	}
 	return value
 }
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
@@ -1,46 +1,61 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/sagemakerruntime"
+	"github.com/google/uuid"
+
+	"github.com/whisper-sagemaker/api/internal/audiosource"
+	"github.com/whisper-sagemaker/api/internal/transcript"
 	"github.com/whisper-sagemaker/api/src/config"
+	"github.com/whisper-sagemaker/api/src/jobstore"
 	"github.com/whisper-sagemaker/api/src/utils"
 )
 
+var allowedFormats = []string{".mp3", ".wav", ".m4a", ".flac", ".ogg", ".webm"}
+
 type Handler struct {
-	cfg              *config.Config
-	logger           *utils.Logger
-	sagemakerClient  *sagemakerruntime.Client
+	cfg             *config.Config
+	logger          *utils.Logger
+	sagemakerClient *sagemakerruntime.Client
+	s3Client        *s3.Client
+	jobStore        jobstore.Store
 }
 
 type TranscriptionResponse struct {
-	Text     string  `json:"text"`
-	Language string  `json:"language,omitempty"`
-	Duration float64 `json:"duration,omitempty"`
+	Text     string               `json:"text"`
+	Language string               `json:"language,omitempty"`
+	Duration float64              `json:"duration,omitempty"`
+	Segments []transcript.Segment `json:"segments,omitempty"`
 }
 
 // SageMaker response format from the inference endpoint
 type SageMakerResponse struct {
-	Success      bool                       `json:"success"`
-	Transcription string                     `json:"transcription"`
+	Success       bool                      `json:"success"`
+	Transcription string                    `json:"transcription"`
 	Metadata      SageMakerResponseMetadata `json:"metadata"`
+	Segments      []transcript.Segment      `json:"segments,omitempty"`
 }
 
 type SageMakerResponseMetadata struct {
-	Language                 string  `json:"language"`
-	Task                     string  `json:"task"`
-	Model                    string  `json:"model"`
-	InferenceTimeSeconds     float64 `json:"inference_time_seconds"`
-	AudioDurationSeconds     float64 `json:"audio_duration_seconds"`
+	Language             string  `json:"language"`
+	Task                 string  `json:"task"`
+	Model                string  `json:"model"`
+	InferenceTimeSeconds float64 `json:"inference_time_seconds"`
+	AudioDurationSeconds float64 `json:"audio_duration_seconds"`
 }
 
 type ErrorResponse struct {
@@ -52,19 +67,43 @@ type HealthResponse struct {
 	Endpoint string `json:"endpoint"`
 }
 
+// AsyncTranscriptionResponse is returned from POST /transcribe/async: the
+// job isn't done yet, so the client is handed an id and a URL to poll.
+type AsyncTranscriptionResponse struct {
+	JobID     string `json:"jobId"`
+	StatusURL string `json:"statusUrl"`
+}
+
+// StatusResponse is returned from GET /status/{jobId}.
+type StatusResponse struct {
+	JobID         string                 `json:"jobId"`
+	Status        jobstore.State         `json:"status"`
+	Error         string                 `json:"error,omitempty"`
+	Transcription *TranscriptionResponse `json:"transcription,omitempty"`
+}
+
 func NewHandler(cfg *config.Config, logger *utils.Logger) *Handler {
 	// Load AWS configuration
 	awsCfg, err := awsconfig.LoadDefaultConfig(context.TODO(),
 		awsconfig.WithRegion(cfg.AWSRegion),
 	)
 	if err != nil {
-		logger.Fatal("Failed to load AWS config:", err)
+		logger.Fatal("Failed to load AWS config", "error", err)
+	}
+
+	var store jobstore.Store
+	if cfg.JobStoreRedisAddr != "" {
+		store = jobstore.NewRedisStore(cfg.JobStoreRedisAddr, cfg.JobStoreRedisPrefix)
+	} else {
+		store = jobstore.NewMemoryStore()
 	}
 
 	return &Handler{
 		cfg:             cfg,
 		logger:          logger,
 		sagemakerClient: sagemakerruntime.NewFromConfig(awsCfg),
+		s3Client:        s3.NewFromConfig(awsCfg),
+		jobStore:        store,
 	}
 }
 
@@ -84,83 +123,306 @@ func (h *Handler) Transcribe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parse multipart form
-	err := r.ParseMultipartForm(h.cfg.MaxFileSize)
+	requestID := utils.RequestIDFromContext(r.Context())
+
+	filename, audioData, err := h.readAudio(r)
 	if err != nil {
-		h.logger.Error("Failed to parse multipart form:", err)
-		h.sendError(w, "Failed to parse form data", http.StatusBadRequest)
+		h.logger.Error("Failed to read audio upload", "request_id", requestID, "error", err)
+		h.sendError(w, err.Error(), statusForSourceError(err))
 		return
 	}
 
-	// Get the uploaded file
-	file, header, err := r.FormFile("audio")
+	h.logger.Info("Processing audio file",
+		"request_id", requestID,
+		"filename", filename,
+		"bytes_in", len(audioData),
+	)
+
+	format := transcript.Negotiate(r.Header.Get("Accept"), r.URL.Query().Get("format"))
+
+	// Invoke SageMaker endpoint
+	transcription, err := h.invokeSageMaker(r.Context(), audioData, format.NeedsTimestamps())
 	if err != nil {
-		h.logger.Error("Failed to get audio file:", err)
-		h.sendError(w, "No audio file provided", http.StatusBadRequest)
+		h.logger.Error("SageMaker invocation failed",
+			"request_id", requestID,
+			"error", err,
+		)
+		h.sendError(w, "Transcription failed: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
-	defer file.Close()
 
-	// Validate file extension
-	ext := strings.ToLower(filepath.Ext(header.Filename))
-	allowedFormats := []string{".mp3", ".wav", ".m4a", ".flac", ".ogg", ".webm"}
-	if !contains(allowedFormats, ext) {
-		h.sendError(w, fmt.Sprintf("Unsupported audio format: %s. Allowed formats: %v", ext, allowedFormats), http.StatusBadRequest)
+	h.writeTranscription(w, format, transcription)
+}
+
+// writeTranscription renders a completed transcription in the format
+// negotiated via the Accept header or ?format= query param.
+func (h *Handler) writeTranscription(w http.ResponseWriter, format transcript.Format, transcription *TranscriptionResponse) {
+	switch format {
+	case transcript.FormatVTT:
+		w.Header().Set("Content-Type", string(transcript.FormatVTT))
+		io.WriteString(w, transcript.RenderVTT(transcription.Segments))
+
+	case transcript.FormatSRT:
+		w.Header().Set("Content-Type", string(transcript.FormatSRT))
+		io.WriteString(w, transcript.RenderSRT(transcription.Segments))
+
+	case transcript.FormatPlain:
+		w.Header().Set("Content-Type", string(transcript.FormatPlain))
+		io.WriteString(w, transcript.RenderPlain(transcription.Segments, transcription.Text))
+
+	case transcript.FormatSSE:
+		h.streamSSE(w, transcription)
+
+	default:
+		w.Header().Set("Content-Type", string(transcript.FormatJSON))
+		json.NewEncoder(w).Encode(transcription)
+	}
+}
+
+// streamSSE renders transcription as a server-sent-events stream, one
+// "data:" event per segment followed by a final "done" event. This is NOT
+// progressive streaming: invokeSageMaker is a single blocking call that
+// only returns once the whole transcription is complete, so every segment
+// is already known by the time streamSSE starts writing. The SSE framing
+// exists so clients can consume segments incrementally on the wire and
+// start rendering captions before the full JSON body has arrived, not
+// because the server produces them incrementally itself.
+func (h *Handler) streamSSE(w http.ResponseWriter, transcription *TranscriptionResponse) {
+	w.Header().Set("Content-Type", string(transcript.FormatSSE))
+	w.Header().Set("Cache-Control", "no-cache")
+
+	flusher, canFlush := w.(http.Flusher)
+
+	for _, segment := range transcription.Segments {
+		data, err := json.Marshal(segment)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		if canFlush {
+			flusher.Flush()
+		}
+	}
+	fmt.Fprint(w, "event: done\ndata: {}\n\n")
+	if canFlush {
+		flusher.Flush()
+	}
+}
+
+// TranscribeAsync uploads the audio to the configured S3 input bucket and
+// kicks off a SageMaker async inference job, returning immediately with a
+// job id the client polls via GET /status/{jobId}. It exists for audio
+// longer than the ~60s sync invocation limit.
+func (h *Handler) TranscribeAsync(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Validate file size
-	if header.Size > h.cfg.MaxFileSize {
-		h.sendError(w, fmt.Sprintf("File size exceeds maximum of %d MB", h.cfg.MaxFileSize/(1024*1024)), http.StatusBadRequest)
+	if h.cfg.AsyncS3InputURI == "" {
+		h.sendError(w, "Async transcription is not configured", http.StatusServiceUnavailable)
 		return
 	}
 
-	h.logger.Info(fmt.Sprintf("Processing audio file: %s (%d bytes)", header.Filename, header.Size))
+	requestID := utils.RequestIDFromContext(r.Context())
 
-	// Read file content
-	audioData, err := io.ReadAll(file)
+	filename, audioData, err := h.readAudio(r)
 	if err != nil {
-		h.logger.Error("Failed to read audio file:", err)
-		h.sendError(w, "Failed to read audio file", http.StatusInternalServerError)
+		h.logger.Error("Failed to read audio upload", "request_id", requestID, "error", err)
+		h.sendError(w, err.Error(), statusForSourceError(err))
 		return
 	}
 
-	// Invoke SageMaker endpoint
-	transcription, err := h.invokeSageMaker(audioData)
+	jobID := uuid.NewString()
+
+	inputBucket, inputPrefix, err := audiosource.ParseS3URI(h.cfg.AsyncS3InputURI)
 	if err != nil {
-		h.logger.Error("SageMaker invocation failed:", err)
-		h.sendError(w, "Transcription failed: "+err.Error(), http.StatusInternalServerError)
+		h.logger.Error("Invalid ASYNC_S3_INPUT_URI", "request_id", requestID, "error", err)
+		h.sendError(w, "Async transcription is misconfigured", http.StatusInternalServerError)
+		return
+	}
+	inputKey := strings.TrimPrefix(inputPrefix+"/"+jobID+filepath.Ext(filename), "/")
+
+	ctx := r.Context()
+	if _, err := h.s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(inputBucket),
+		Key:    aws.String(inputKey),
+		Body:   bytes.NewReader(audioData),
+	}); err != nil {
+		h.logger.Error("Failed to upload audio to S3", "request_id", requestID, "error", err)
+		h.sendError(w, "Failed to stage audio for async transcription", http.StatusInternalServerError)
+		return
+	}
+
+	result, err := h.sagemakerClient.InvokeEndpointAsync(ctx, &sagemakerruntime.InvokeEndpointAsyncInput{
+		EndpointName:  aws.String(h.cfg.SageMakerEndpoint),
+		ContentType:   aws.String("application/octet-stream"),
+		InputLocation: aws.String(fmt.Sprintf("s3://%s/%s", inputBucket, inputKey)),
+		InferenceId:   aws.String(jobID),
+	})
+	if err != nil {
+		h.logger.Error("SageMaker async invocation failed", "request_id", requestID, "error", err)
+		h.sendError(w, "Failed to start async transcription: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Send response
+	job := &jobstore.Job{
+		ID:        jobID,
+		InputKey:  inputKey,
+		OutputKey: aws.ToString(result.OutputLocation),
+		CreatedAt: time.Now(),
+		State:     jobstore.StatePending,
+	}
+	if err := h.jobStore.Create(ctx, job); err != nil {
+		h.logger.Error("Failed to persist async job", "request_id", requestID, "error", err)
+		h.sendError(w, "Failed to start async transcription", http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Info("Async transcription job started",
+		"request_id", requestID,
+		"job_id", jobID,
+		"sagemaker_endpoint", h.cfg.SageMakerEndpoint,
+	)
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(transcription)
+	json.NewEncoder(w).Encode(AsyncTranscriptionResponse{
+		JobID:     jobID,
+		StatusURL: "/status/" + jobID,
+	})
 }
 
 func (h *Handler) Status(w http.ResponseWriter, r *http.Request) {
-	// Extract job ID from path
 	jobID := strings.TrimPrefix(r.URL.Path, "/status/")
-
 	if jobID == "" {
 		h.sendError(w, "Job ID required", http.StatusBadRequest)
 		return
 	}
 
-	// For now, return a simple response
-	// In a real implementation, you would check job status from a database or cache
-	response := map[string]string{
-		"jobId":  jobID,
-		"status": "not_implemented",
-		"message": "Async processing not yet implemented",
+	requestID := utils.RequestIDFromContext(r.Context())
+	ctx := r.Context()
+
+	job, err := h.jobStore.Get(ctx, jobID)
+	if err == jobstore.ErrNotFound {
+		h.sendError(w, "Job not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to load job", "request_id", requestID, "job_id", jobID, "error", err)
+		h.sendError(w, "Failed to look up job", http.StatusInternalServerError)
+		return
+	}
+
+	if job.State == jobstore.StatePending {
+		job = h.pollAsyncResult(ctx, job)
+	}
+
+	response := StatusResponse{JobID: job.ID, Status: job.State, Error: job.Error}
+	if job.State == jobstore.StateCompleted && len(job.Result) > 0 {
+		var transcription TranscriptionResponse
+		if err := json.Unmarshal(job.Result, &transcription); err == nil {
+			response.Transcription = &transcription
+		}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
-func (h *Handler) invokeSageMaker(audioData []byte) (*TranscriptionResponse, error) {
-	ctx := context.TODO()
+// pollAsyncResult checks whether SageMaker has written an output (or
+// failure) object for a still-pending job, transitioning and persisting
+// its state if so. Jobs that are genuinely still running are returned
+// unchanged.
+func (h *Handler) pollAsyncResult(ctx context.Context, job *jobstore.Job) *jobstore.Job {
+	outputBucket, outputKey, err := audiosource.ParseS3URI(job.OutputKey)
+	if err != nil {
+		h.logger.Error("Invalid job output location", "job_id", job.ID, "error", err)
+		return job
+	}
+
+	if body, err := h.getS3Object(ctx, outputBucket, outputKey); err == nil {
+		transcription, _, parseErr := parseSageMakerResponse(body)
+		if parseErr != nil {
+			job.State = jobstore.StateFailed
+			job.Error = parseErr.Error()
+		} else {
+			result, _ := json.Marshal(transcription)
+			job.State = jobstore.StateCompleted
+			job.Result = result
+		}
+		if updateErr := h.jobStore.Update(ctx, job); updateErr != nil {
+			h.logger.Error("Failed to update job", "job_id", job.ID, "error", updateErr)
+		}
+		return job
+	}
+
+	failureKey := outputKey + ".failure"
+	if body, err := h.getS3Object(ctx, outputBucket, failureKey); err == nil {
+		job.State = jobstore.StateFailed
+		job.Error = string(body)
+		if updateErr := h.jobStore.Update(ctx, job); updateErr != nil {
+			h.logger.Error("Failed to update job", "job_id", job.ID, "error", updateErr)
+		}
+	}
+
+	return job
+}
+
+func (h *Handler) getS3Object(ctx context.Context, bucket, key string) ([]byte, error) {
+	result, err := h.s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer result.Body.Close()
+	return io.ReadAll(result.Body)
+}
+
+// readAudio binds the request body to an audiosource.Source based on its
+// Content-Type (multipart upload, raw octet-stream, or a JSON body naming
+// an S3 object/URL/base64 payload) and reads it fully into memory. Format
+// and size validation happen inside the source so every input path shares
+// the same limits.
+func (h *Handler) readAudio(r *http.Request) (filename string, audioData []byte, err error) {
+	opts := audiosource.Options{
+		AllowedExtensions: allowedFormats,
+		MaxFileSize:       h.cfg.MaxFileSize,
+	}
+
+	src, err := audiosource.Bind(r, opts, h.s3Client)
+	if err != nil {
+		return "", nil, err
+	}
+
+	reader, filename, _, err := src.Open(r.Context())
+	if err != nil {
+		return "", nil, err
+	}
+	defer reader.Close()
+
+	audioData, err = io.ReadAll(reader)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read audio: %w", err)
+	}
+
+	return filename, audioData, nil
+}
+
+// statusForSourceError maps an audiosource validation failure (including a
+// malformed multipart body) to 400 and anything else (a failed upstream
+// fetch or S3 read) to 500, since those aren't the caller's fault.
+func statusForSourceError(err error) int {
+	var validationErr *audiosource.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func (h *Handler) invokeSageMaker(ctx context.Context, audioData []byte, needsTimestamps bool) (*TranscriptionResponse, error) {
+	requestID := utils.RequestIDFromContext(ctx)
 
 	// Invoke the SageMaker endpoint
 	input := &sagemakerruntime.InvokeEndpointInput{
@@ -168,33 +430,54 @@ func (h *Handler) invokeSageMaker(audioData []byte) (*TranscriptionResponse, err
 		ContentType:  aws.String("application/octet-stream"),
 		Body:         audioData,
 	}
+	if needsTimestamps {
+		input.CustomAttributes = aws.String(`{"return_timestamps":true,"word_timestamps":true}`)
+	}
 
-	h.logger.Info("Invoking SageMaker endpoint...")
+	h.logger.Info("Invoking SageMaker endpoint",
+		"request_id", requestID,
+		"sagemaker_endpoint", h.cfg.SageMakerEndpoint,
+	)
+	start := time.Now()
 	result, err := h.sagemakerClient.InvokeEndpoint(ctx, input)
 	if err != nil {
 		return nil, fmt.Errorf("SageMaker invocation error: %w", err)
 	}
 
-	// Parse SageMaker response
+	transcription, inferenceTimeSeconds, err := parseSageMakerResponse(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	h.logger.Info("Transcription completed successfully",
+		"request_id", requestID,
+		"sagemaker_endpoint", h.cfg.SageMakerEndpoint,
+		"inference_time_seconds", inferenceTimeSeconds,
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+	return transcription, nil
+}
+
+// parseSageMakerResponse decodes the JSON body returned by the inference
+// endpoint, shared by the sync invocation path and async result polling. It
+// also surfaces the endpoint-reported inference_time_seconds so callers can
+// log it without re-parsing the raw body themselves.
+func parseSageMakerResponse(body []byte) (*TranscriptionResponse, float64, error) {
 	var sagemakerResp SageMakerResponse
-	if err := json.Unmarshal(result.Body, &sagemakerResp); err != nil {
-		return nil, fmt.Errorf("failed to parse SageMaker response: %w", err)
+	if err := json.Unmarshal(body, &sagemakerResp); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse SageMaker response: %w", err)
 	}
 
-	// Check if transcription was successful
 	if !sagemakerResp.Success {
-		return nil, fmt.Errorf("transcription failed")
+		return nil, 0, fmt.Errorf("transcription failed")
 	}
 
-	// Convert to API response format
-	transcription := &TranscriptionResponse{
+	return &TranscriptionResponse{
 		Text:     sagemakerResp.Transcription,
 		Language: sagemakerResp.Metadata.Language,
 		Duration: sagemakerResp.Metadata.AudioDurationSeconds,
-	}
-
-	h.logger.Info("Transcription completed successfully")
-	return transcription, nil
+		Segments: sagemakerResp.Segments,
+	}, sagemakerResp.Metadata.InferenceTimeSeconds, nil
 }
 
 func (h *Handler) sendError(w http.ResponseWriter, message string, statusCode int) {
@@ -202,12 +485,3 @@ func (h *Handler) sendError(w http.ResponseWriter, message string, statusCode in
 	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(ErrorResponse{Error: message})
 }
-
-func contains(slice []string, item string) bool {
-	for _, s := range slice {
-		if s == item {
-			return true
-		}
-	}
-	return false
-}
@@ -0,0 +1,43 @@
+// Package jobstore persists the state of in-flight async transcription
+// jobs so Handler.Status can resolve a jobId to an input/output S3
+// location. With the Redis-backed store this works regardless of which API
+// instance handled the original /transcribe/async request; the default
+// in-memory store only sees jobs started by the same process.
+package jobstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by Store.Get when no job exists for the given ID.
+var ErrNotFound = errors.New("jobstore: job not found")
+
+// State is the lifecycle stage of an async transcription job.
+type State string
+
+const (
+	StatePending   State = "pending"
+	StateCompleted State = "completed"
+	StateFailed    State = "failed"
+)
+
+// Job is the record tracked for a single async transcription request.
+type Job struct {
+	ID        string          `json:"id"`
+	InputKey  string          `json:"input_key"`
+	OutputKey string          `json:"output_key"`
+	CreatedAt time.Time       `json:"created_at"`
+	State     State           `json:"state"`
+	Result    json.RawMessage `json:"result,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// Store is implemented by the in-memory and Redis-backed job stores.
+type Store interface {
+	Create(ctx context.Context, job *Job) error
+	Get(ctx context.Context, id string) (*Job, error)
+	Update(ctx context.Context, job *Job) error
+}
@@ -0,0 +1,48 @@
+package jobstore
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore is the default Store: a process-local map. Job state is lost
+// on restart, which is fine for single-instance deployments but not for
+// anything load-balanced — use RedisStore there.
+type MemoryStore struct {
+	mu   sync.RWMutex
+	jobs map[string]*Job
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{jobs: make(map[string]*Job)}
+}
+
+func (s *MemoryStore) Create(ctx context.Context, job *Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clone := *job
+	s.jobs[job.ID] = &clone
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, id string) (*Job, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	clone := *job
+	return &clone, nil
+}
+
+func (s *MemoryStore) Update(ctx context.Context, job *Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[job.ID]; !ok {
+		return ErrNotFound
+	}
+	clone := *job
+	s.jobs[job.ID] = &clone
+	return nil
+}
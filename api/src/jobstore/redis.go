@@ -0,0 +1,60 @@
+package jobstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore shares job state across API instances. Keys are namespaced
+// by prepending prefix to the job ID (see key), so multiple stores can
+// safely share one Redis database.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisStore(addr, prefix string) *RedisStore {
+	return &RedisStore{
+		client: redis.NewClient(&redis.Options{Addr: addr}),
+		prefix: prefix,
+	}
+}
+
+func (s *RedisStore) key(id string) string {
+	return s.prefix + id
+}
+
+func (s *RedisStore) Create(ctx context.Context, job *Job) error {
+	return s.put(ctx, job)
+}
+
+func (s *RedisStore) Update(ctx context.Context, job *Job) error {
+	return s.put(ctx, job)
+}
+
+func (s *RedisStore) put(ctx context.Context, job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("jobstore: marshal job: %w", err)
+	}
+	return s.client.Set(ctx, s.key(job.ID), data, 0).Err()
+}
+
+func (s *RedisStore) Get(ctx context.Context, id string) (*Job, error) {
+	data, err := s.client.Get(ctx, s.key(id)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("jobstore: get job: %w", err)
+	}
+
+	var job Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, fmt.Errorf("jobstore: unmarshal job: %w", err)
+	}
+	return &job, nil
+}
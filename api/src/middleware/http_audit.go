@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/whisper-sagemaker/api/src/config"
+	"github.com/whisper-sagemaker/api/src/utils"
+)
+
+// truncatedMarker is appended to any captured body that was cut off at
+// HTTPLogMaxBody so readers of the audit log know the record is partial.
+const truncatedMarker = "...[truncated]"
+
+// boundedBuffer tees writes into an in-memory buffer capped at limit bytes;
+// everything past the cap is counted but discarded, so a single large
+// upload or response can't blow up the audit log's memory footprint.
+type boundedBuffer struct {
+	buf       bytes.Buffer
+	limit     int64
+	total     int64
+	truncated bool
+}
+
+func (b *boundedBuffer) Write(p []byte) (int, error) {
+	b.total += int64(len(p))
+	if remaining := b.limit - int64(b.buf.Len()); remaining > 0 {
+		if int64(len(p)) > remaining {
+			b.buf.Write(p[:remaining])
+			b.truncated = true
+		} else {
+			b.buf.Write(p)
+		}
+	} else if len(p) > 0 {
+		b.truncated = true
+	}
+	return len(p), nil
+}
+
+func (b *boundedBuffer) String() string {
+	s := b.buf.String()
+	if b.truncated {
+		s += truncatedMarker
+	}
+	return s
+}
+
+// teeReadCloser tees reads into a boundedBuffer while still returning every
+// byte read to the caller, so ParseMultipartForm/FormFile see the exact
+// same stream they would without auditing.
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// auditResponseWriter tees everything written to the client into a
+// boundedBuffer before flushing it through to the real ResponseWriter.
+type auditResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   *boundedBuffer
+}
+
+func (w *auditResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *auditResponseWriter) Write(p []byte) (int, error) {
+	w.body.Write(p)
+	return w.ResponseWriter.Write(p)
+}
+
+// LogHTTP captures request metadata (headers, multipart part names, audio
+// filename/size/content-type) and response bodies, up to HTTPLogMaxBody
+// bytes, for every request, writing them as JSON records to a rotating file. It is gated by
+// cfg.HTTPLogEnabled and intended to run alongside Logging to give
+// operators an audit trail of every /transcribe call without touching
+// handler code.
+func LogHTTP(cfg *config.Config) func(http.Handler) http.Handler {
+	if !cfg.HTTPLogEnabled {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	sink := utils.NewRotatingFileSink(cfg.HTTPLogPath, cfg.HTTPLogMaxSize, 0, 0, cfg.HTTPLogUseGzip)
+	encoderCfg := zap.NewProductionEncoderConfig()
+	encoderCfg.TimeKey = "timestamp"
+	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(encoderCfg), sink, zapcore.InfoLevel)
+	auditLogger := zap.New(core).Sugar()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			reqBody := &boundedBuffer{limit: cfg.HTTPLogMaxBody}
+			if r.Body != nil {
+				r.Body = &teeReadCloser{Reader: io.TeeReader(r.Body, reqBody), Closer: r.Body}
+			}
+
+			arw := &auditResponseWriter{
+				ResponseWriter: w,
+				status:         http.StatusOK,
+				body:           &boundedBuffer{limit: cfg.HTTPLogMaxBody},
+			}
+
+			next.ServeHTTP(arw, r)
+
+			audioName, audioContentType, partNames := inspectMultipart(r.Header.Get("Content-Type"), reqBody)
+
+			auditLogger.Infow("http transcribe audit",
+				"request_id", utils.RequestIDFromContext(r.Context()),
+				"method", r.Method,
+				"path", r.URL.Path,
+				"headers", utils.RedactHeaders(r.Header),
+				"content_type", r.Header.Get("Content-Type"),
+				"content_length", r.ContentLength,
+				"multipart_parts", partNames,
+				"audio_filename", audioName,
+				"audio_content_type", audioContentType,
+				"request_body_truncated", reqBody.truncated,
+				"status", arw.status,
+				"duration_ms", time.Since(start).Milliseconds(),
+				"response_body", arw.body.String(),
+			)
+		})
+	}
+}
+
+// inspectMultipart walks as much of the captured request prefix as was
+// buffered and returns the first audio part's filename/content-type plus
+// the names of every part header it managed to read before running out of
+// buffered bytes.
+func inspectMultipart(contentType string, body *boundedBuffer) (filename, partContentType string, partNames []string) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/form-data" {
+		return "", "", nil
+	}
+
+	reader := multipart.NewReader(bytes.NewReader(body.buf.Bytes()), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+		partNames = append(partNames, part.FormName())
+		if part.FormName() == "audio" && part.FileName() != "" && filename == "" {
+			filename = part.FileName()
+			partContentType = part.Header.Get("Content-Type")
+		}
+		part.Close()
+	}
+	return filename, partContentType, partNames
+}
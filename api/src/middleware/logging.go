@@ -7,6 +7,8 @@ import (
 	"github.com/whisper-sagemaker/api/src/utils"
 )
 
+const RequestIDHeader = "X-Request-ID"
+
 type responseWriter struct {
 	http.ResponseWriter
 	status int
@@ -24,11 +26,21 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return size, err
 }
 
+// Logging generates/propagates an X-Request-ID for every request, injects
+// it into the request context so downstream handlers can emit correlated
+// log lines, and logs a structured summary once the request completes.
 func Logging(logger *utils.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
 
+			requestID := r.Header.Get(RequestIDHeader)
+			if requestID == "" {
+				requestID = utils.NewRequestID()
+			}
+			w.Header().Set(RequestIDHeader, requestID)
+			r = r.WithContext(utils.WithRequestID(r.Context(), requestID))
+
 			rw := &responseWriter{
 				ResponseWriter: w,
 				status:         http.StatusOK,
@@ -36,12 +48,14 @@ func Logging(logger *utils.Logger) func(http.Handler) http.Handler {
 
 			next.ServeHTTP(rw, r)
 
-			logger.Info(
+			logger.Info("request completed",
+				"request_id", requestID,
 				"method", r.Method,
 				"path", r.URL.Path,
 				"status", rw.status,
-				"duration", time.Since(start),
-				"size", rw.size,
+				"duration_ms", time.Since(start).Milliseconds(),
+				"bytes_in", r.ContentLength,
+				"bytes_out", rw.size,
 			)
 		})
 	}
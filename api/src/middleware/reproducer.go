@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/whisper-sagemaker/api/src/config"
+	"github.com/whisper-sagemaker/api/src/reproducer"
+	"github.com/whisper-sagemaker/api/src/utils"
+)
+
+// teeResponseWriter captures the response body alongside the real write so
+// a captured failure can be diffed against a later replay.
+type teeResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *teeResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *teeResponseWriter) Write(p []byte) (int, error) {
+	w.body.Write(p)
+	return w.ResponseWriter.Write(p)
+}
+
+// Reproducer captures the raw request (method, URL, headers, body) for any
+// /transcribe call that returns a 5xx, so it can be replayed later against
+// a staging endpoint with cmd/repro-replay. Successful requests are never
+// captured, keeping disk usage bounded by the store's retention budget.
+func Reproducer(cfg *config.Config, store *reproducer.Store, logger *utils.Logger) func(http.Handler) http.Handler {
+	if !cfg.ReproducerEnabled {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/transcribe" || r.Body == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Buffer at most one byte past MaxFileSize for the capture, and
+			// hand the handler the buffered prefix followed by the unread
+			// rest of the body, so an oversized body still reaches the
+			// handler intact and gets the clean 400 the audiosource
+			// size-validation path produces, instead of being truncated
+			// here first and failing downstream for the wrong reason.
+			bodyBytes, err := io.ReadAll(io.LimitReader(r.Body, cfg.MaxFileSize+1))
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			r.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(bodyBytes), r.Body), r.Body}
+
+			rw := &teeResponseWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rw, r)
+
+			if rw.status < 500 {
+				return
+			}
+
+			if _, err := store.Capture(r.Method, r.URL.String(), r.Header, bodyBytes, rw.status, rw.body.String()); err != nil {
+				logger.Error("Failed to capture request for replay",
+					"request_id", utils.RequestIDFromContext(r.Context()),
+					"error", err,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Package reproducer captures failed /transcribe requests to disk as
+// self-describing archives (one directory per request, meta.json + raw
+// body.bin) so they can be replayed against a staging endpoint without
+// needing the original client. It is opt-in via REPRODUCER_ENABLED since
+// it duplicates audio uploads to disk.
+package reproducer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/whisper-sagemaker/api/src/utils"
+)
+
+// MetaFileName and BodyFileName are the two files written per capture,
+// named so the companion cmd/repro-replay CLI can walk the directory tree
+// without guessing extensions.
+const (
+	MetaFileName = "meta.json"
+	BodyFileName = "body.bin"
+)
+
+// Meta describes a single captured request, along with the response it
+// produced at capture time so a replay can be diffed against it.
+type Meta struct {
+	ID               string      `json:"id"`
+	Timestamp        time.Time   `json:"timestamp"`
+	Method           string      `json:"method"`
+	URL              string      `json:"url"`
+	Headers          http.Header `json:"headers"`
+	BodySize         int64       `json:"body_size"`
+	OriginalStatus   int         `json:"original_status"`
+	OriginalResponse string      `json:"original_response"`
+}
+
+// Store writes captures under Dir, evicting the oldest ones once MaxCount
+// or MaxTotalBytes is exceeded.
+type Store struct {
+	Dir           string
+	MaxCount      int
+	MaxTotalBytes int64
+}
+
+func NewStore(dir string, maxCount int, maxTotalBytes int64) *Store {
+	return &Store{Dir: dir, MaxCount: maxCount, MaxTotalBytes: maxTotalBytes}
+}
+
+// Capture writes a new archive for a failed request and evicts the oldest
+// archives until the store is back within its retention budget.
+func (s *Store) Capture(method, url string, headers http.Header, body []byte, originalStatus int, originalResponse string) (string, error) {
+	id := time.Now().UTC().Format("20060102T150405.000000000Z") + "-" + utils.NewRequestID()[:8]
+	dir := filepath.Join(s.Dir, id)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("reproducer: create capture dir: %w", err)
+	}
+
+	meta := Meta{
+		ID:               id,
+		Timestamp:        time.Now(),
+		Method:           method,
+		URL:              url,
+		Headers:          utils.RedactHeaders(headers),
+		BodySize:         int64(len(body)),
+		OriginalStatus:   originalStatus,
+		OriginalResponse: originalResponse,
+	}
+	metaBytes, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("reproducer: marshal meta: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, MetaFileName), metaBytes, 0o644); err != nil {
+		return "", fmt.Errorf("reproducer: write meta: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, BodyFileName), body, 0o644); err != nil {
+		return "", fmt.Errorf("reproducer: write body: %w", err)
+	}
+
+	s.evict()
+	return id, nil
+}
+
+type capture struct {
+	path    string
+	modTime time.Time
+	size    int64
+}
+
+// evict removes the oldest capture directories until the store satisfies
+// both MaxCount and MaxTotalBytes.
+func (s *Store) evict() {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return
+	}
+
+	captures := make([]capture, 0, len(entries))
+	var total int64
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(s.Dir, entry.Name())
+		size := dirSize(path)
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		captures = append(captures, capture{path: path, modTime: info.ModTime(), size: size})
+		total += size
+	}
+
+	sort.Slice(captures, func(i, j int) bool { return captures[i].modTime.Before(captures[j].modTime) })
+
+	for len(captures) > 0 && (len(captures) > s.MaxCount || (s.MaxTotalBytes > 0 && total > s.MaxTotalBytes)) {
+		oldest := captures[0]
+		os.RemoveAll(oldest.path)
+		total -= oldest.size
+		captures = captures[1:]
+	}
+}
+
+func dirSize(dir string) int64 {
+	var size int64
+	filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
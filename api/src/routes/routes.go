@@ -6,6 +6,7 @@ import (
 	"github.com/whisper-sagemaker/api/src/config"
 	"github.com/whisper-sagemaker/api/src/handlers"
 	"github.com/whisper-sagemaker/api/src/middleware"
+	"github.com/whisper-sagemaker/api/src/reproducer"
 	"github.com/whisper-sagemaker/api/src/utils"
 )
 
@@ -15,10 +16,16 @@ func SetupRoutes(cfg *config.Config, logger *utils.Logger) http.Handler {
 	// Create handler instance
 	h := handlers.NewHandler(cfg, logger)
 
+	repro := reproducer.NewStore(cfg.ReproducerDir, cfg.ReproducerMaxCount, cfg.ReproducerMaxBytes)
+
 	// Apply middleware
 	handler := middleware.CORS(cfg)(
 		middleware.Logging(logger)(
-			mux,
+			middleware.LogHTTP(cfg)(
+				middleware.Reproducer(cfg, repro, logger)(
+					mux,
+				),
+			),
 		),
 	)
 
@@ -28,6 +35,9 @@ func SetupRoutes(cfg *config.Config, logger *utils.Logger) http.Handler {
 	// Transcription endpoint
 	mux.HandleFunc("/transcribe", h.Transcribe)
 
+	// Async transcription endpoint, for audio longer than the sync invocation limit
+	mux.HandleFunc("/transcribe/async", h.TranscribeAsync)
+
 	// Status endpoint (for async operations)
 	mux.HandleFunc("/status/", h.Status)
 
@@ -0,0 +1,19 @@
+package utils
+
+import "net/http"
+
+// RedactHeaders copies h, replacing sensitive header values with a
+// placeholder so callers that persist or log headers (the HTTP audit
+// middleware, the reproducer capture archive) never write credentials to
+// disk in cleartext.
+func RedactHeaders(h http.Header) map[string][]string {
+	redacted := make(map[string][]string, len(h))
+	for k, v := range h {
+		if k == "Authorization" || k == "Cookie" {
+			redacted[k] = []string{"[redacted]"}
+			continue
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
@@ -1,32 +1,102 @@
 package utils
 
 import (
-	"log"
 	"os"
+	"strconv"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
+// Logger wraps a zap.SugaredLogger so call sites can pass structured
+// key/value pairs (e.g. "request_id", id, "status", 200) instead of
+// building strings with fmt.Sprintf.
 type Logger struct {
-	*log.Logger
+	*zap.SugaredLogger
 }
 
-func NewLogger() *Logger {
-	return &Logger{
-		Logger: log.New(os.Stdout, "[API] ", log.LstdFlags|log.Lshortfile),
+// NewLogger builds a structured JSON logger. Level is controlled by
+// LOG_LEVEL (debug/info/warn/error). When LOG_FILE_PATH is set, output is
+// rotated on disk via lumberjack in addition to stdout; rotation is
+// configured through LOG_FILE_MAX_SIZE (MB), LOG_FILE_MAX_BACKUPS,
+// LOG_FILE_MAX_AGE (days) and LOG_FILE_COMPRESS.
+func NewLogger(logLevel string) *Logger {
+	level := parseLevel(logLevel)
+
+	encoderCfg := zap.NewProductionEncoderConfig()
+	encoderCfg.TimeKey = "timestamp"
+	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	encoder := zapcore.NewJSONEncoder(encoderCfg)
+
+	cores := []zapcore.Core{
+		zapcore.NewCore(encoder, zapcore.AddSync(os.Stdout), level),
+	}
+
+	if path := os.Getenv("LOG_FILE_PATH"); path != "" {
+		sink := NewRotatingFileSink(
+			path,
+			getEnvAsInt("LOG_FILE_MAX_SIZE", 100),
+			getEnvAsInt("LOG_FILE_MAX_BACKUPS", 3),
+			getEnvAsInt("LOG_FILE_MAX_AGE", 28),
+			getEnvAsBool("LOG_FILE_COMPRESS", true),
+		)
+		cores = append(cores, zapcore.NewCore(encoder, sink, level))
 	}
+
+	core := zapcore.NewTee(cores...)
+	zapLogger := zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))
+
+	return &Logger{SugaredLogger: zapLogger.Sugar()}
+}
+
+// Fatal logs at fatal level and then terminates the process, matching the
+// behavior of the previous log.Logger-backed implementation.
+func (l *Logger) Fatal(msg string, keysAndValues ...interface{}) {
+	l.SugaredLogger.Fatalw(msg, keysAndValues...)
+}
+
+// Info logs msg at info level with the given key/value pairs.
+func (l *Logger) Info(msg string, keysAndValues ...interface{}) {
+	l.SugaredLogger.Infow(msg, keysAndValues...)
+}
+
+// Error logs msg at error level with the given key/value pairs.
+func (l *Logger) Error(msg string, keysAndValues ...interface{}) {
+	l.SugaredLogger.Errorw(msg, keysAndValues...)
 }
 
-func (l *Logger) Info(v ...interface{}) {
-	l.Println("[INFO]", v)
+// Debug logs msg at debug level with the given key/value pairs.
+func (l *Logger) Debug(msg string, keysAndValues ...interface{}) {
+	l.SugaredLogger.Debugw(msg, keysAndValues...)
 }
 
-func (l *Logger) Error(v ...interface{}) {
-	l.Println("[ERROR]", v)
+func parseLevel(logLevel string) zapcore.Level {
+	switch logLevel {
+	case "debug":
+		return zapcore.DebugLevel
+	case "warn":
+		return zapcore.WarnLevel
+	case "error":
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.InfoLevel
+	}
 }
 
-func (l *Logger) Debug(v ...interface{}) {
-	l.Println("[DEBUG]", v)
+func getEnvAsInt(key string, defaultValue int) int {
+	if v := os.Getenv(key); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
 }
 
-func (l *Logger) Fatal(v ...interface{}) {
-	l.Fatalln("[FATAL]", v)
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if v := os.Getenv(key); v != "" {
+		if parsed, err := strconv.ParseBool(v); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
 }
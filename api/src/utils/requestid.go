@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+type contextKey int
+
+const requestIDKey contextKey = iota
+
+// NewRequestID generates a random identifier suitable for the
+// X-Request-ID header and for correlating log lines across middleware
+// and handlers.
+func NewRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("%x", b)
+}
+
+// WithRequestID returns a context carrying requestID.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, if any.
+func RequestIDFromContext(ctx context.Context) string {
+	if requestID, ok := ctx.Value(requestIDKey).(string); ok {
+		return requestID
+	}
+	return ""
+}
@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// NewRotatingFileSink wraps a lumberjack.Logger (size/age/backup-count based
+// rotation, optional gzip of rotated files) as a zapcore.WriteSyncer so it
+// can be plugged into any zapcore.Core as a sink.
+func NewRotatingFileSink(path string, maxSizeMB, maxBackups, maxAgeDays int, compress bool) zapcore.WriteSyncer {
+	return zapcore.AddSync(&lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    maxSizeMB,
+		MaxBackups: maxBackups,
+		MaxAge:     maxAgeDays,
+		Compress:   compress,
+	})
+}